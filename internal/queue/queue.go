@@ -76,6 +76,12 @@ func (q QueuedNode) Resolve(config walker.Config) (QueuedNode, error) {
 	if err != nil {
 		return QueuedNode{}, err
 	}
+	if q.Progress.Budget != nil {
+		if q.Progress.Budget.LinkBudget <= 0 {
+			return QueuedNode{}, walker.ErrBudgetExceeded{BudgetKind: "link", Path: q.Path, Link: lnk}
+		}
+		q.Progress.Budget.LinkBudget--
+	}
 	// Pick what in-memory format we will build.
 	np, err := config.LinkTargetNodePrototypeChooser(lnk, lnkCtx)
 	if err != nil {
@@ -89,11 +95,15 @@ func (q QueuedNode) Resolve(config walker.Config) (QueuedNode, error) {
 		}
 		return QueuedNode{}, fmt.Errorf("error traversing node at %q: could not load link %q: %s", q.Path, lnk, err)
 	}
+	if config.ResumeState != nil {
+		config.ResumeState.Record(q.Path, lnk)
+	}
 	return QueuedNode{
 		Node:     n,
 		Selector: q.Selector,
 		Progress: walker.Progress{
-			Path: q.Path,
+			Path:   q.Path,
+			Budget: q.Progress.Budget,
 			LastBlock: struct {
 				Path ipld.Path
 				Link ipld.Link
@@ -114,10 +124,62 @@ func (q QueuedNode) ResolveParameters(config walker.Config) (ipld.Link, ipld.Lin
 	}, nil
 }
 
+// FastForwardTo advances root along p one segment at a time, using state to decide
+// which links along the way it may load, without enumerating or loading any siblings a
+// normal selector-driven descent would otherwise enqueue. Inline (non-link) segments
+// are crossed without consulting state. It stops either once p is fully consumed (the
+// resume point has been reached, and normal exploration should take over from the
+// returned QueuedNode) or as soon as it reaches an unloaded link at a path state has
+// no record for (the caller should fall back to a normal walk from there, since state
+// can't tell us anything more about previously-unvisited territory).
+func FastForwardTo(config walker.Config, root QueuedNode, state *walker.TraversalResumerPathState, p ipld.Path) (QueuedNode, ipld.Path, error) {
+	q := root
+	segs := p.Segments()
+	for i, seg := range segs {
+		if !q.IsResolved() {
+			if _, ok := state.Lookup(q.Path); !ok {
+				return q, ipld.NewPath(segs[i:]), nil
+			}
+			resolved, err := q.Resolve(config)
+			if err != nil {
+				return QueuedNode{}, ipld.Path{}, err
+			}
+			q = resolved
+		}
+		sNext := q.Selector.Explore(q.Node, seg)
+		if sNext == nil {
+			return QueuedNode{}, ipld.Path{}, fmt.Errorf("resume path %q: selector does not explore segment %q", p, seg)
+		}
+		v, err := q.Node.LookupBySegment(seg)
+		if err != nil {
+			return QueuedNode{}, ipld.Path{}, fmt.Errorf("resume path %q: %w", p, err)
+		}
+		progNext := q.Progress
+		progNext.Path = q.Progress.Path.AppendSegment(seg)
+		q = QueuedNode{Node: v, Selector: sNext, Progress: progNext}
+	}
+	if !q.IsResolved() {
+		if _, ok := state.Lookup(q.Path); ok {
+			resolved, err := q.Resolve(config)
+			if err != nil {
+				return QueuedNode{}, ipld.Path{}, err
+			}
+			q = resolved
+		}
+	}
+	return q, ipld.Path{}, nil
+}
+
 func (q QueuedNode) Visit(fn walker.AdvVisitFn) error {
 	if !q.IsResolved() {
 		return errors.New("Cannot visit unresolved nodes")
 	}
+	if q.Progress.Budget != nil {
+		if q.Progress.Budget.NodeBudget <= 0 {
+			return walker.ErrBudgetExceeded{BudgetKind: "node", Path: q.Path}
+		}
+		q.Progress.Budget.NodeBudget--
+	}
 	n := q.Node
 	s := q.Selector
 	if s.Decide(n) {
@@ -132,7 +194,7 @@ func (q QueuedNode) Visit(fn walker.AdvVisitFn) error {
 	return nil
 }
 
-func (q QueuedNode) Children() ([]QueuedNode, error) {
+func (q QueuedNode) Children(config walker.Config) ([]QueuedNode, error) {
 	if !q.IsResolved() {
 		return nil, errors.New("Cannot get children of unresolved node")
 	}
@@ -145,10 +207,42 @@ func (q QueuedNode) Children() ([]QueuedNode, error) {
 		return nil, nil
 	}
 	attn := s.Interests()
+	var newNodes []QueuedNode
+	var err error
 	if attn == nil {
-		return q.walkAdv_iterateAll()
+		newNodes, err = q.walkAdv_iterateAll()
+	} else {
+		newNodes, err = q.walkAdv_iterateSelective(attn)
+	}
+	if err != nil {
+		return nil, err
+	}
+	preload(config, newNodes)
+	return newNodes, nil
+}
+
+// preload notifies config.Preloader, if set, of every not-yet-resolved link among
+// newNodes -- the batch of children about to enter the queue -- so a caller-supplied
+// fetcher can start pulling them in before they're dequeued and resolved.
+func preload(config walker.Config, newNodes []QueuedNode) {
+	if config.Preloader == nil {
+		return
+	}
+	links := make([]walker.PreloadLink, 0, len(newNodes))
+	for _, child := range newNodes {
+		if child.IsResolved() {
+			continue
+		}
+		lnk, err := child.Node.AsLink()
+		if err != nil {
+			continue
+		}
+		links = append(links, walker.PreloadLink{Path: child.Path, Link: lnk})
+	}
+	if len(links) == 0 {
+		return
 	}
-	return q.walkAdv_iterateSelective(attn)
+	config.Preloader(config.Ctx, links)
 }
 
 func (q QueuedNode) walkAdv_iterateAll() ([]QueuedNode, error) {
@@ -164,6 +258,7 @@ func (q QueuedNode) walkAdv_iterateAll() ([]QueuedNode, error) {
 		if sNext != nil {
 			progNext := q.Progress
 			progNext.Path = q.Progress.Path.AppendSegment(ps)
+			progNext.Budget = progNext.Budget.Clone()
 			newNodes = append(newNodes, QueuedNode{v, sNext, progNext})
 		}
 	}
@@ -183,6 +278,7 @@ func (q QueuedNode) walkAdv_iterateSelective(attn []ipld.PathSegment) ([]QueuedN
 		if sNext != nil {
 			progNext := q.Progress
 			progNext.Path = q.Progress.Path.AppendSegment(ps)
+			progNext.Budget = progNext.Budget.Clone()
 			newNodes = append(newNodes, QueuedNode{v, sNext, progNext})
 		}
 	}
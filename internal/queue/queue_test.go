@@ -0,0 +1,262 @@
+package queue_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-ipld-prime"
+	_ "github.com/ipld/go-ipld-prime/codec/dagcbor"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+	"github.com/ipld/go-ipld-prime/traversal"
+	"github.com/ipld/go-ipld-prime/traversal/selector"
+	"github.com/ipld/go-ipld-prime/traversal/selector/builder"
+	"github.com/multiformats/go-multihash"
+
+	"github.com/ipld/go-walker/internal/queue"
+	walker "github.com/ipld/go-walker/pkg"
+)
+
+var testResumeCidPrefix = cid.Prefix{
+	Version:  1,
+	Codec:    cid.DagCBOR,
+	MhType:   multihash.SHA2_256,
+	MhLength: -1,
+}
+
+func TestVisitNodeBudgetExceeded(t *testing.T) {
+	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype.Any)
+	sel, err := ssb.Matcher().Selector()
+	if err != nil {
+		t.Fatalf("building selector: %s", err)
+	}
+
+	n := basicnode.NewString("hello")
+	q := queue.QueuedNode{
+		Node:     n,
+		Selector: sel,
+		Progress: walker.Progress{
+			Path:   ipld.ParsePath("a/b"),
+			Budget: &walker.Budget{NodeBudget: 0, LinkBudget: 1},
+		},
+	}
+
+	err = q.Visit(func(walker.Progress, ipld.Node, traversal.VisitReason) error {
+		t.Fatal("visitor should not be called once the node budget is exhausted")
+		return nil
+	})
+
+	var budgetErr walker.ErrBudgetExceeded
+	if !errors.As(err, &budgetErr) {
+		t.Fatalf("expected ErrBudgetExceeded, got %v", err)
+	}
+	if budgetErr.BudgetKind != "node" {
+		t.Fatalf("expected node budget kind, got %q", budgetErr.BudgetKind)
+	}
+	if budgetErr.Path.String() != "a/b" {
+		t.Fatalf("expected path a/b, got %q", budgetErr.Path)
+	}
+}
+
+func TestResolveLinkBudgetExceeded(t *testing.T) {
+	c, err := cid.Decode("bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi")
+	if err != nil {
+		t.Fatalf("decoding test cid: %s", err)
+	}
+	lnk := cidlink.Link{Cid: c}
+
+	nb := basicnode.Prototype.Link.NewBuilder()
+	if err := nb.AssignLink(lnk); err != nil {
+		t.Fatalf("assigning link: %s", err)
+	}
+
+	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype.Any)
+	sel, err := ssb.Matcher().Selector()
+	if err != nil {
+		t.Fatalf("building selector: %s", err)
+	}
+
+	q := queue.QueuedNode{
+		Node:     nb.Build(),
+		Selector: sel,
+		Progress: walker.Progress{
+			Path:   ipld.ParsePath("x"),
+			Budget: &walker.Budget{NodeBudget: 1, LinkBudget: 0},
+		},
+	}
+
+	_, err = q.Resolve(walker.Config{})
+	var budgetErr walker.ErrBudgetExceeded
+	if !errors.As(err, &budgetErr) {
+		t.Fatalf("expected ErrBudgetExceeded, got %v", err)
+	}
+	if budgetErr.BudgetKind != "link" {
+		t.Fatalf("expected link budget kind, got %q", budgetErr.BudgetKind)
+	}
+	if budgetErr.Path.String() != "x" {
+		t.Fatalf("expected path x, got %q", budgetErr.Path)
+	}
+	if budgetErr.Link == nil || budgetErr.Link.String() != lnk.String() {
+		t.Fatalf("expected link %q in error, got %v", lnk, budgetErr.Link)
+	}
+}
+
+// TestFastForwardToCrossesListAncestor reproduces the scenario flagged in review: the
+// resume path crosses a List ancestor, so the segment recorded during the original
+// walk is int-tagged (ipld.PathSegmentOfInt, same as walkAdv_iterateAll constructs for
+// list children) while the segment in the resume path comes from ipld.ParsePath, which
+// only ever produces string-tagged segments.
+func TestFastForwardToCrossesListAncestor(t *testing.T) {
+	blocks := make(map[string][]byte)
+	lsys := cidlink.DefaultLinkSystem()
+	lsys.StorageWriteOpener = func(lnkCtx ipld.LinkContext) (io.Writer, ipld.BlockWriteCommitter, error) {
+		var buf bytes.Buffer
+		return &buf, func(lnk ipld.Link) error {
+			blocks[lnk.(cidlink.Link).Cid.KeyString()] = buf.Bytes()
+			return nil
+		}, nil
+	}
+	lsys.StorageReadOpener = func(lnkCtx ipld.LinkContext, lnk ipld.Link) (io.Reader, error) {
+		data, ok := blocks[lnk.(cidlink.Link).Cid.KeyString()]
+		if !ok {
+			return nil, errors.New("no such block")
+		}
+		return bytes.NewReader(data), nil
+	}
+
+	childNb := basicnode.Prototype.Map.NewBuilder()
+	childMa, err := childNb.BeginMap(1)
+	if err != nil {
+		t.Fatalf("begin child map: %s", err)
+	}
+	childMa.AssembleKey().AssignString("leaf")
+	childMa.AssembleValue().AssignString("hello")
+	if err := childMa.Finish(); err != nil {
+		t.Fatalf("finish child map: %s", err)
+	}
+	childLnk, err := lsys.Store(ipld.LinkContext{Ctx: context.Background()}, cidlink.LinkPrototype{Prefix: testResumeCidPrefix}, childNb.Build())
+	if err != nil {
+		t.Fatalf("store child: %s", err)
+	}
+
+	nb := basicnode.Prototype.List.NewBuilder()
+	la, err := nb.BeginList(2)
+	if err != nil {
+		t.Fatalf("begin list: %s", err)
+	}
+	la.AssembleValue().AssignString("skip-me")
+	if err := la.AssembleValue().AssignLink(childLnk); err != nil {
+		t.Fatalf("assigning link: %s", err)
+	}
+	if err := la.Finish(); err != nil {
+		t.Fatalf("finish list: %s", err)
+	}
+	root := nb.Build()
+
+	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype.Any)
+	sel, err := ssb.ExploreRecursive(selector.RecursionLimitDepth(16), ssb.ExploreUnion(
+		ssb.Matcher(),
+		ssb.ExploreAll(ssb.ExploreRecursiveEdge()),
+	)).Selector()
+	if err != nil {
+		t.Fatalf("building selector: %s", err)
+	}
+
+	config := walker.Config{
+		Ctx:                            context.Background(),
+		LinkSystem:                     lsys,
+		LinkTargetNodePrototypeChooser: func(ipld.Link, ipld.LinkContext) (ipld.NodePrototype, error) { return basicnode.Prototype.Any, nil },
+	}
+
+	// Drive one real walk over the root, as queue.QueuedNode.Resolve would during an
+	// ordinary traversal, so the list child gets recorded under its real, int-tagged path.
+	state := walker.NewTraversalResumerPathState()
+	config.ResumeState = state
+	rootQ := queue.QueuedNode{Node: root, Selector: sel}
+	children, err := rootQ.Children(config)
+	if err != nil {
+		t.Fatalf("Children: %s", err)
+	}
+	if len(children) != 2 {
+		t.Fatalf("expected 2 children, got %d", len(children))
+	}
+	linkChild := children[1]
+	if _, err := linkChild.Resolve(config); err != nil {
+		t.Fatalf("Resolve: %s", err)
+	}
+
+	// Now fast-forward a fresh walk straight to "1/leaf", using only the state tree
+	// recorded above and a resume path built the way a caller would (ipld.ParsePath,
+	// which produces string-tagged segments throughout).
+	fresh := queue.QueuedNode{Node: root, Selector: sel}
+	result, remaining, err := queue.FastForwardTo(config, fresh, state, ipld.ParsePath("1/leaf"))
+	if err != nil {
+		t.Fatalf("FastForwardTo: %s", err)
+	}
+	if remaining.Len() != 0 {
+		t.Fatalf("expected the resume path to be fully consumed, %d segments left over", remaining.Len())
+	}
+	s, err := result.Node.AsString()
+	if err != nil {
+		t.Fatalf("AsString: %s", err)
+	}
+	if s != "hello" {
+		t.Fatalf("expected to fast-forward to the leaf value %q, got %q", "hello", s)
+	}
+}
+
+func TestChildrenInvokesPreloaderWithLinks(t *testing.T) {
+	c, err := cid.Decode("bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi")
+	if err != nil {
+		t.Fatalf("decoding test cid: %s", err)
+	}
+	lnk := cidlink.Link{Cid: c}
+
+	nb := basicnode.Prototype.Map.NewBuilder()
+	ma, err := nb.BeginMap(2)
+	if err != nil {
+		t.Fatalf("begin map: %s", err)
+	}
+	ma.AssembleKey().AssignString("inline")
+	ma.AssembleValue().AssignString("hello")
+	ma.AssembleKey().AssignString("linked")
+	if err := ma.AssembleValue().AssignLink(lnk); err != nil {
+		t.Fatalf("assigning link: %s", err)
+	}
+	if err := ma.Finish(); err != nil {
+		t.Fatalf("finish map: %s", err)
+	}
+
+	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype.Any)
+	sel, err := ssb.ExploreAll(ssb.Matcher()).Selector()
+	if err != nil {
+		t.Fatalf("building selector: %s", err)
+	}
+
+	q := queue.QueuedNode{Node: nb.Build(), Selector: sel}
+
+	var preloaded []walker.PreloadLink
+	config := walker.Config{
+		Ctx: context.Background(),
+		Preloader: func(ctx context.Context, links []walker.PreloadLink) {
+			preloaded = append(preloaded, links...)
+		},
+	}
+
+	if _, err := q.Children(config); err != nil {
+		t.Fatalf("Children: %s", err)
+	}
+	if len(preloaded) != 1 {
+		t.Fatalf("expected exactly one preloaded link (the inline field has none), got %d", len(preloaded))
+	}
+	if preloaded[0].Path.String() != "linked" {
+		t.Fatalf("expected preload path %q, got %q", "linked", preloaded[0].Path)
+	}
+	if preloaded[0].Link.String() != lnk.String() {
+		t.Fatalf("expected preload link %q, got %q", lnk, preloaded[0].Link)
+	}
+}
@@ -21,8 +21,21 @@ func NewControlledWalk(config walker.Config) (*ControlledWalker, error) {
 		nextNode: queue.QueuedNode{
 			Node:     config.Root,
 			Selector: config.Selector,
+			Progress: walker.Progress{Budget: config.Budget.Clone()},
 		},
 	}
+	if config.ResumeAt.Len() > 0 {
+		if config.ResumeState == nil {
+			return nil, errors.New("Config.ResumeAt requires Config.ResumeState")
+		}
+		fastForwarded, _, err := queue.FastForwardTo(config, cw.nextNode, config.ResumeState, config.ResumeAt)
+		if err != nil {
+			return nil, err
+		}
+		// Any unconsumed remainder of ResumeAt is previously-unvisited territory: fall
+		// back to letting normal selector exploration carry on from here.
+		cw.nextNode = fastForwarded
+	}
 	cw.config.LinkSystem.StorageReadOpener = cw.storageReadOpener
 	return cw, nil
 }
@@ -60,15 +73,47 @@ func (t *ControlledWalker) resolve() error {
 
 // IsComplete returns true if a traversal is complete
 func (t *ControlledWalker) IsComplete() (bool, error) {
+	if t.isDone {
+		return true, t.completionErr
+	}
+	if t.config.Ctx != nil {
+		if err := t.config.Ctx.Err(); err != nil {
+			t.isDone = true
+			t.completionErr = err
+			return true, err
+		}
+	}
 	t.isDone, t.completionErr = t.nextLink()
 	return t.isDone, t.completionErr
 }
 
+// Shutdown immediately terminates the traversal: it releases the queue, marks the
+// walker done, and causes any subsequent call to Advance, CurrentRequest, or
+// IsComplete to return ErrCancelled; a subsequent call to Error does nothing. If the
+// walk has already completed, Shutdown does nothing and the earlier completion error
+// (if any) is kept. This is useful when the traversal is being driven
+// by an outer request/response loop (e.g. a graphsync-style executor) whose context
+// can be cancelled independently of the block-load handshake.
+func (t *ControlledWalker) Shutdown() {
+	if t.isDone {
+		return
+	}
+	t.isDone = true
+	t.completionErr = ErrCancelled
+	t.queue = nil
+}
+
+// ErrCancelled is the completion error left behind by Shutdown.
+var ErrCancelled = errors.New("controlled walk: cancelled")
+
 // CurrentRequest returns the current block load waiting to be fulfilled in order
 // to advance further
 func (t *ControlledWalker) CurrentRequest() (ipld.Link, ipld.LinkContext, error) {
-	isComplete, _ := t.IsComplete()
+	isComplete, err := t.IsComplete()
 	if isComplete {
+		if err != nil {
+			return nil, ipld.LinkContext{}, err
+		}
 		return nil, ipld.LinkContext{}, errors.New("traversal done no current request")
 	}
 	return t.nextNode.ResolveParameters(t.config)
@@ -76,8 +121,11 @@ func (t *ControlledWalker) CurrentRequest() (ipld.Link, ipld.LinkContext, error)
 
 // Advance advances the traversal with an io.Reader for the next requested block
 func (t *ControlledWalker) Advance(reader io.Reader) error {
-	isComplete, _ := t.IsComplete()
+	isComplete, err := t.IsComplete()
 	if isComplete {
+		if err != nil {
+			return err
+		}
 		return errors.New("cannot advance when done")
 	}
 	t.nextResponse = nextResponse{reader, nil}
@@ -86,8 +134,7 @@ func (t *ControlledWalker) Advance(reader io.Reader) error {
 
 // Error aborts the traversal with an error for the next block load
 func (t *ControlledWalker) Error(err error) {
-	isComplete, _ := t.IsComplete()
-	if isComplete {
+	if isComplete, _ := t.IsComplete(); isComplete {
 		return
 	}
 	t.nextResponse = nextResponse{nil, err}
@@ -102,7 +149,7 @@ func (t *ControlledWalker) nextLink() (bool, error) {
 				return true, err
 			}
 		}
-		newNodes, err := t.nextNode.Children()
+		newNodes, err := t.nextNode.Children(t.config)
 		if err != nil {
 			return true, err
 		}
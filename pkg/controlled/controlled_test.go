@@ -0,0 +1,112 @@
+package controlled_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+	"github.com/ipld/go-ipld-prime/traversal/selector/builder"
+
+	walker "github.com/ipld/go-walker/pkg"
+	"github.com/ipld/go-walker/pkg/controlled"
+)
+
+func TestIsCompleteSurfacesContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype.Any)
+	sel, err := ssb.Matcher().Selector()
+	if err != nil {
+		t.Fatalf("building selector: %s", err)
+	}
+
+	cw, err := controlled.NewControlledWalk(walker.Config{
+		Ctx:        ctx,
+		LinkSystem: cidlink.DefaultLinkSystem(),
+		Root:       basicnode.NewString("hello"),
+		Selector:   sel,
+	})
+	if err != nil {
+		t.Fatalf("NewControlledWalk: %s", err)
+	}
+
+	done, err := cw.IsComplete()
+	if !done {
+		t.Fatalf("expected IsComplete to report done once Ctx is cancelled")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected IsComplete to surface context.Canceled, got %v", err)
+	}
+
+	if _, _, err := cw.CurrentRequest(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected CurrentRequest to surface context.Canceled, got %v", err)
+	}
+	if err := cw.Advance(nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected Advance to surface context.Canceled, got %v", err)
+	}
+}
+
+func TestShutdownMidWalk(t *testing.T) {
+	c, err := cid.Decode("bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi")
+	if err != nil {
+		t.Fatalf("decoding test cid: %s", err)
+	}
+	lnk := cidlink.Link{Cid: c}
+
+	nb := basicnode.Prototype.Map.NewBuilder()
+	ma, err := nb.BeginMap(1)
+	if err != nil {
+		t.Fatalf("begin map: %s", err)
+	}
+	ma.AssembleKey().AssignString("child")
+	if err := ma.AssembleValue().AssignLink(lnk); err != nil {
+		t.Fatalf("assigning link: %s", err)
+	}
+	if err := ma.Finish(); err != nil {
+		t.Fatalf("finish map: %s", err)
+	}
+	root := nb.Build()
+
+	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype.Any)
+	sel, err := ssb.ExploreAll(ssb.Matcher()).Selector()
+	if err != nil {
+		t.Fatalf("building selector: %s", err)
+	}
+
+	cw, err := controlled.NewControlledWalk(walker.Config{
+		Ctx:        context.Background(),
+		LinkSystem: cidlink.DefaultLinkSystem(),
+		Root:       root,
+		Selector:   sel,
+	})
+	if err != nil {
+		t.Fatalf("NewControlledWalk: %s", err)
+	}
+
+	// Drive the walk up to the point where it's waiting on the child link load, so
+	// Shutdown is exercised mid-walk rather than before anything has happened.
+	done, err := cw.IsComplete()
+	if done {
+		t.Fatalf("expected the walk to still be waiting on the child link load, got done with err %v", err)
+	}
+	if _, _, err := cw.CurrentRequest(); err != nil {
+		t.Fatalf("expected a pending request for the child link, got %s", err)
+	}
+
+	cw.Shutdown()
+
+	done, err = cw.IsComplete()
+	if !done || !errors.Is(err, controlled.ErrCancelled) {
+		t.Fatalf("expected IsComplete to report ErrCancelled after Shutdown, got (%v, %v)", done, err)
+	}
+	if _, _, err := cw.CurrentRequest(); !errors.Is(err, controlled.ErrCancelled) {
+		t.Fatalf("expected CurrentRequest to surface ErrCancelled after Shutdown, got %v", err)
+	}
+	if err := cw.Advance(nil); !errors.Is(err, controlled.ErrCancelled) {
+		t.Fatalf("expected Advance to surface ErrCancelled after Shutdown, got %v", err)
+	}
+}
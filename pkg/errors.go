@@ -0,0 +1,22 @@
+package walker
+
+import (
+	"fmt"
+
+	"github.com/ipld/go-ipld-prime"
+)
+
+// ErrBudgetExceeded is returned when a traversal configured with Config.Budget runs
+// out of either its NodeBudget or its LinkBudget before the walk completes.
+type ErrBudgetExceeded struct {
+	BudgetKind string    // "node" or "link"
+	Path       ipld.Path // the path at which the budget ran out.
+	Link       ipld.Link // set when BudgetKind is "link"; nil otherwise.
+}
+
+func (e ErrBudgetExceeded) Error() string {
+	if e.Link != nil {
+		return fmt.Sprintf("traversal budget exceeded: %s budget reached zero at path %q loading link %q", e.BudgetKind, e.Path, e.Link)
+	}
+	return fmt.Sprintf("traversal budget exceeded: %s budget reached zero at path %q", e.BudgetKind, e.Path)
+}
@@ -0,0 +1,65 @@
+// Package focus provides a cheap point-lookup alternative to the selector-driven
+// walkers in pkg/controlled and pkg/transform, for callers who just want the node at
+// one known path.
+package focus
+
+import (
+	"fmt"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-walker/internal/queue"
+	walker "github.com/ipld/go-walker/pkg"
+)
+
+// Focus walks from config.Root to p, transparently crossing links via config.LinkSystem
+// and config.LinkTargetNodePrototypeChooser, and calls fn with the node found there.
+//
+// It reuses queue.QueuedNode.Resolve for link loading and path tracking, and (if
+// Config.Budget is set) spends Config.Budget's LinkBudget on each link it loads, as the
+// selector-driven walkers do; NodeBudget is not consumed, since no node is visited
+// through queue.QueuedNode.Visit. It iterates segments directly rather than driving a
+// selector.
+func Focus(config walker.Config, p ipld.Path, fn walker.VisitFn) error {
+	n, prog, err := get(config, p)
+	if err != nil {
+		return err
+	}
+	return fn(prog, n)
+}
+
+// Get is like Focus, but returns the node directly instead of invoking a visitor.
+func Get(config walker.Config, p ipld.Path) (ipld.Node, error) {
+	n, _, err := get(config, p)
+	return n, err
+}
+
+func get(config walker.Config, p ipld.Path) (ipld.Node, walker.Progress, error) {
+	q := queue.QueuedNode{
+		Node:     config.Root,
+		Progress: walker.Progress{Budget: config.Budget.Clone()},
+	}
+	for _, seg := range p.Segments() {
+		if !q.IsResolved() {
+			resolved, err := q.Resolve(config)
+			if err != nil {
+				return nil, walker.Progress{}, err
+			}
+			q = resolved
+		}
+		v, err := q.Node.LookupBySegment(seg)
+		if err != nil {
+			return nil, walker.Progress{}, fmt.Errorf("error focusing on %q: could not traverse segment %q: %w", p, seg, err)
+		}
+		prog := q.Progress
+		prog.Path = q.Progress.Path.AppendSegment(seg)
+		q = queue.QueuedNode{Node: v, Progress: prog}
+	}
+	if !q.IsResolved() {
+		resolved, err := q.Resolve(config)
+		if err != nil {
+			return nil, walker.Progress{}, err
+		}
+		q = resolved
+	}
+	return q.Node, q.Progress, nil
+}
@@ -0,0 +1,121 @@
+package focus_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-ipld-prime"
+	_ "github.com/ipld/go-ipld-prime/codec/dagcbor"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+	"github.com/multiformats/go-multihash"
+
+	walker "github.com/ipld/go-walker/pkg"
+	"github.com/ipld/go-walker/pkg/focus"
+)
+
+func TestGetNestedField(t *testing.T) {
+	nb := basicnode.Prototype.Map.NewBuilder()
+	ma, err := nb.BeginMap(1)
+	if err != nil {
+		t.Fatalf("begin map: %s", err)
+	}
+	ma.AssembleKey().AssignString("a")
+	ia, err := ma.AssembleValue().BeginMap(1)
+	if err != nil {
+		t.Fatalf("begin inner map: %s", err)
+	}
+	ia.AssembleKey().AssignString("b")
+	ia.AssembleValue().AssignString("hello")
+	if err := ia.Finish(); err != nil {
+		t.Fatalf("finish inner map: %s", err)
+	}
+	if err := ma.Finish(); err != nil {
+		t.Fatalf("finish map: %s", err)
+	}
+	root := nb.Build()
+
+	n, err := focus.Get(walker.Config{Root: root}, ipld.ParsePath("a/b"))
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	s, err := n.AsString()
+	if err != nil {
+		t.Fatalf("AsString: %s", err)
+	}
+	if s != "hello" {
+		t.Fatalf("expected %q, got %q", "hello", s)
+	}
+}
+
+func TestGetRespectsBudget(t *testing.T) {
+	blocks := make(map[string][]byte)
+	lsys := cidlink.DefaultLinkSystem()
+	lsys.StorageWriteOpener = func(lnkCtx ipld.LinkContext) (io.Writer, ipld.BlockWriteCommitter, error) {
+		var buf bytes.Buffer
+		return &buf, func(lnk ipld.Link) error {
+			blocks[lnk.(cidlink.Link).Cid.KeyString()] = buf.Bytes()
+			return nil
+		}, nil
+	}
+	lsys.StorageReadOpener = func(lnkCtx ipld.LinkContext, lnk ipld.Link) (io.Reader, error) {
+		data, ok := blocks[lnk.(cidlink.Link).Cid.KeyString()]
+		if !ok {
+			return nil, fmt.Errorf("no block for %q", lnk)
+		}
+		return bytes.NewReader(data), nil
+	}
+
+	childNb := basicnode.Prototype.Map.NewBuilder()
+	childMa, err := childNb.BeginMap(1)
+	if err != nil {
+		t.Fatalf("begin child map: %s", err)
+	}
+	childMa.AssembleKey().AssignString("leaf")
+	childMa.AssembleValue().AssignString("hello")
+	if err := childMa.Finish(); err != nil {
+		t.Fatalf("finish child map: %s", err)
+	}
+	childLnk, err := lsys.Store(ipld.LinkContext{Ctx: context.Background()}, cidlink.LinkPrototype{Prefix: cid.Prefix{
+		Version: 1, Codec: cid.DagCBOR, MhType: multihash.SHA2_256, MhLength: -1,
+	}}, childNb.Build())
+	if err != nil {
+		t.Fatalf("store child: %s", err)
+	}
+
+	nb := basicnode.Prototype.Map.NewBuilder()
+	ma, err := nb.BeginMap(1)
+	if err != nil {
+		t.Fatalf("begin map: %s", err)
+	}
+	ma.AssembleKey().AssignString("child")
+	if err := ma.AssembleValue().AssignLink(childLnk); err != nil {
+		t.Fatalf("assigning link: %s", err)
+	}
+	if err := ma.Finish(); err != nil {
+		t.Fatalf("finish map: %s", err)
+	}
+	root := nb.Build()
+
+	config := walker.Config{
+		Ctx:                            context.Background(),
+		LinkSystem:                     lsys,
+		LinkTargetNodePrototypeChooser: func(ipld.Link, ipld.LinkContext) (ipld.NodePrototype, error) { return basicnode.Prototype.Any, nil },
+		Root:                           root,
+		Budget:                         &walker.Budget{NodeBudget: 1, LinkBudget: 0},
+	}
+
+	_, err = focus.Get(config, ipld.ParsePath("child/leaf"))
+	var budgetErr walker.ErrBudgetExceeded
+	if !errors.As(err, &budgetErr) {
+		t.Fatalf("expected ErrBudgetExceeded, got %v", err)
+	}
+	if budgetErr.BudgetKind != "link" {
+		t.Fatalf("expected link budget kind, got %q", budgetErr.BudgetKind)
+	}
+}
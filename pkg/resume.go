@@ -0,0 +1,130 @@
+package walker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+)
+
+// TraversalResumerPathState is a tree, keyed by the string form of an ipld.PathSegment,
+// whose leaves record the ipld.Link that was loaded at that path during a walk. It's
+// keyed by seg.String() rather than the raw ipld.PathSegment because segments for List
+// children are int-tagged (ipld.PathSegmentOfInt) during a live walk but string-tagged
+// (ipld.PathSegmentOfString) everywhere a lookup key is built, and those don't compare
+// equal as map keys despite sharing the same string form.
+//
+// It is populated as a side effect of an ordinary walk (queue.QueuedNode.Resolve
+// records into it whenever Config.ResumeState is set), and can later be handed back in
+// via Config.ResumeState together with Config.ResumeAt to fast-forward a new walk
+// straight to that path. The same tree can be reused across any number of walks over
+// the same selector+DAG, since it only ever grows.
+type TraversalResumerPathState struct {
+	Link     ipld.Link
+	Children map[string]*TraversalResumerPathState
+}
+
+// NewTraversalResumerPathState creates an empty state tree, ready to be populated by a walk.
+func NewTraversalResumerPathState() *TraversalResumerPathState {
+	return &TraversalResumerPathState{}
+}
+
+// Record stores the link loaded at p, creating intermediate tree nodes as needed.
+func (t *TraversalResumerPathState) Record(p ipld.Path, lnk ipld.Link) {
+	node := t
+	for _, seg := range p.Segments() {
+		if node.Children == nil {
+			node.Children = make(map[string]*TraversalResumerPathState)
+		}
+		key := seg.String()
+		next, ok := node.Children[key]
+		if !ok {
+			next = &TraversalResumerPathState{}
+			node.Children[key] = next
+		}
+		node = next
+	}
+	node.Link = lnk
+}
+
+// Lookup returns the link recorded at p, if any prior walk recorded one there.
+func (t *TraversalResumerPathState) Lookup(p ipld.Path) (ipld.Link, bool) {
+	node := t
+	for _, seg := range p.Segments() {
+		if node.Children == nil {
+			return nil, false
+		}
+		next, ok := node.Children[seg.String()]
+		if !ok {
+			return nil, false
+		}
+		node = next
+	}
+	if node.Link == nil {
+		return nil, false
+	}
+	return node.Link, true
+}
+
+// serializedPathState is the JSON-friendly shape used by MarshalState /
+// UnmarshalTraversalResumerPathState; ipld.Link isn't itself JSON-marshalable, so it's
+// flattened to a string.
+type serializedPathState struct {
+	Link     string                          `json:"link,omitempty"`
+	Children map[string]*serializedPathState `json:"children,omitempty"`
+}
+
+func (t *TraversalResumerPathState) toSerializable() *serializedPathState {
+	if t == nil {
+		return nil
+	}
+	s := &serializedPathState{}
+	if t.Link != nil {
+		s.Link = t.Link.String()
+	}
+	if len(t.Children) > 0 {
+		s.Children = make(map[string]*serializedPathState, len(t.Children))
+		for key, child := range t.Children {
+			s.Children[key] = child.toSerializable()
+		}
+	}
+	return s
+}
+
+func (s *serializedPathState) toPathState() (*TraversalResumerPathState, error) {
+	t := &TraversalResumerPathState{}
+	if s.Link != "" {
+		c, err := cid.Decode(s.Link)
+		if err != nil {
+			return nil, fmt.Errorf("decoding resume state link %q: %w", s.Link, err)
+		}
+		t.Link = cidlink.Link{Cid: c}
+	}
+	if len(s.Children) > 0 {
+		t.Children = make(map[string]*TraversalResumerPathState, len(s.Children))
+		for key, child := range s.Children {
+			childState, err := child.toPathState()
+			if err != nil {
+				return nil, err
+			}
+			t.Children[key] = childState
+		}
+	}
+	return t, nil
+}
+
+// MarshalState serializes the state tree so it can be persisted between processes.
+func (t *TraversalResumerPathState) MarshalState() ([]byte, error) {
+	return json.Marshal(t.toSerializable())
+}
+
+// UnmarshalTraversalResumerPathState deserializes a state tree previously produced by MarshalState.
+func UnmarshalTraversalResumerPathState(data []byte) (*TraversalResumerPathState, error) {
+	var s serializedPathState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return s.toPathState()
+}
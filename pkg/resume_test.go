@@ -0,0 +1,90 @@
+package walker_test
+
+import (
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+
+	walker "github.com/ipld/go-walker/pkg"
+)
+
+func testLink(t *testing.T) ipld.Link {
+	t.Helper()
+	c, err := cid.Decode("bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi")
+	if err != nil {
+		t.Fatalf("decoding test cid: %s", err)
+	}
+	return cidlink.Link{Cid: c}
+}
+
+func TestRecordLookupRoundTrip(t *testing.T) {
+	lnk := testLink(t)
+	state := walker.NewTraversalResumerPathState()
+	state.Record(ipld.ParsePath("a/b"), lnk)
+
+	got, ok := state.Lookup(ipld.ParsePath("a/b"))
+	if !ok {
+		t.Fatalf("expected a recorded link at a/b")
+	}
+	if got.String() != lnk.String() {
+		t.Fatalf("expected %q, got %q", lnk, got)
+	}
+
+	if _, ok := state.Lookup(ipld.ParsePath("a")); ok {
+		t.Fatalf("did not expect a recorded link at the intermediate path a")
+	}
+	if _, ok := state.Lookup(ipld.ParsePath("a/c")); ok {
+		t.Fatalf("did not expect a recorded link at the unrelated sibling path a/c")
+	}
+}
+
+// TestRecordViaListSegmentLookupViaStringSegment reproduces the scenario a live walk
+// actually hits: queue-driven traversal records a List child using an int-tagged
+// ipld.PathSegment (ipld.PathSegmentOfInt), while a caller's Config.ResumeAt -- parsed
+// with ipld.ParsePath -- only ever produces string-tagged segments. Both must key into
+// the same tree entry.
+func TestRecordViaListSegmentLookupViaStringSegment(t *testing.T) {
+	lnk := testLink(t)
+	state := walker.NewTraversalResumerPathState()
+
+	listSegPath := ipld.NewPath([]ipld.PathSegment{ipld.PathSegmentOfInt(3)})
+	state.Record(listSegPath, lnk)
+
+	got, ok := state.Lookup(ipld.ParsePath("3"))
+	if !ok {
+		t.Fatalf("expected looking up a list index recorded via PathSegmentOfInt to succeed when queried via a string-tagged path segment")
+	}
+	if got.String() != lnk.String() {
+		t.Fatalf("expected %q, got %q", lnk, got)
+	}
+}
+
+func TestMarshalUnmarshalStateRoundTrip(t *testing.T) {
+	lnk := testLink(t)
+	state := walker.NewTraversalResumerPathState()
+	state.Record(ipld.ParsePath("a/b"), lnk)
+	state.Record(ipld.NewPath([]ipld.PathSegment{ipld.PathSegmentOfString("a"), ipld.PathSegmentOfInt(2)}), lnk)
+
+	data, err := state.MarshalState()
+	if err != nil {
+		t.Fatalf("MarshalState: %s", err)
+	}
+
+	restored, err := walker.UnmarshalTraversalResumerPathState(data)
+	if err != nil {
+		t.Fatalf("UnmarshalTraversalResumerPathState: %s", err)
+	}
+
+	got, ok := restored.Lookup(ipld.ParsePath("a/b"))
+	if !ok {
+		t.Fatalf("expected restored state to have a/b")
+	}
+	if got.String() != lnk.String() {
+		t.Fatalf("expected %q, got %q", lnk, got)
+	}
+	if _, ok := restored.Lookup(ipld.ParsePath("a/2")); !ok {
+		t.Fatalf("expected restored state to have the list-indexed entry a/2")
+	}
+}
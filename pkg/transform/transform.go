@@ -0,0 +1,214 @@
+// Package transform provides a walker variant that can rewrite a DAG in place,
+// alongside the read-only pkg/controlled walker.
+package transform
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ipld/go-ipld-prime"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+	"github.com/ipld/go-ipld-prime/traversal"
+	"github.com/ipld/go-walker/internal/queue"
+	walker "github.com/ipld/go-walker/pkg"
+)
+
+// WalkTransforming performs a selector traversal of config.Root, calling
+// config.TransformFn at each selected node and, whenever it returns a replacement,
+// rebuilding every ancestor of that node bottom-up -- re-encoding and re-storing a new
+// block through config.LinkSystem wherever a child crossed a link boundary. It returns
+// the (possibly new) root node, plus every new link that had to be written along the
+// way.
+//
+// This reuses queue.QueuedNode for traversal bookkeeping (path tracking, link
+// resolution, selector exploration) rather than reimplementing it, but drives it with
+// plain recursion instead of controlled.ControlledWalker's queue: since rebuilding a
+// node requires every child to have already been visited and (if replaced)
+// re-encoded, the rebuild itself is always bottom-up regardless of config.Ordering.
+func WalkTransforming(config walker.Config) (ipld.Node, []ipld.Link, error) {
+	if config.TransformFn == nil {
+		return nil, nil, errors.New("transform walk requires Config.TransformFn")
+	}
+	tw := &transformWalker{config: config}
+	root := queue.QueuedNode{
+		Node:     config.Root,
+		Selector: config.Selector,
+		Progress: walker.Progress{Budget: config.Budget.Clone()},
+	}
+	newRoot, _, err := tw.walk(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newRoot, tw.newLinks, nil
+}
+
+type transformWalker struct {
+	config   walker.Config
+	newLinks []ipld.Link
+}
+
+// walk returns the (possibly rewritten) node for q, and whether it differs from
+// q.Node -- callers use the latter to decide whether they need to rebuild in turn.
+func (tw *transformWalker) walk(q queue.QueuedNode) (ipld.Node, bool, error) {
+	if !q.IsResolved() {
+		resolved, err := q.Resolve(tw.config)
+		if err != nil {
+			if _, ok := err.(traversal.SkipMe); ok {
+				return q.Node, false, nil
+			}
+			return nil, false, err
+		}
+		newChild, changed, err := tw.walk(resolved)
+		if err != nil {
+			return nil, false, err
+		}
+		if !changed {
+			return q.Node, false, nil
+		}
+		stored, err := tw.store(resolved.Progress, newChild)
+		if err != nil {
+			return nil, false, err
+		}
+		return stored, true, nil
+	}
+
+	children, err := q.Children(tw.config)
+	if err != nil {
+		return nil, false, err
+	}
+
+	changed := false
+	newChildren := make(map[ipld.PathSegment]ipld.Node, len(children))
+	for _, child := range children {
+		segs := child.Progress.Path.Segments()
+		seg := segs[len(segs)-1]
+		newChildNode, childChanged, err := tw.walk(child)
+		if err != nil {
+			return nil, false, err
+		}
+		if childChanged {
+			changed = true
+			newChildren[seg] = newChildNode
+		}
+	}
+
+	base, visitChanged, err := tw.visit(q)
+	if err != nil {
+		return nil, false, err
+	}
+	if !changed && !visitChanged {
+		return q.Node, false, nil
+	}
+	if len(newChildren) == 0 {
+		return base, true, nil
+	}
+	rebuilt, err := tw.rebuild(base, newChildren)
+	if err != nil {
+		return nil, false, err
+	}
+	return rebuilt, true, nil
+}
+
+// visit applies config.TransformFn at q, enforcing Config.Budget's NodeBudget the same
+// way queue.QueuedNode.Visit does -- it can't reuse Visit directly, since TransformFn
+// (unlike walker.AdvVisitFn) returns a replacement node rather than just an error.
+func (tw *transformWalker) visit(q queue.QueuedNode) (ipld.Node, bool, error) {
+	if q.Progress.Budget != nil {
+		if q.Progress.Budget.NodeBudget <= 0 {
+			return nil, false, walker.ErrBudgetExceeded{BudgetKind: "node", Path: q.Path}
+		}
+		q.Progress.Budget.NodeBudget--
+	}
+	if !q.Selector.Decide(q.Node) {
+		return q.Node, false, nil
+	}
+	newNode, err := tw.config.TransformFn(q.Progress, q.Node)
+	if err != nil {
+		return nil, false, err
+	}
+	if newNode == nil {
+		return q.Node, false, nil
+	}
+	return newNode, true, nil
+}
+
+// rebuild copies n, substituting newChildren at the path segments they key on, using
+// n's own NodePrototype so the result has the same in-memory representation as n did.
+func (tw *transformWalker) rebuild(n ipld.Node, newChildren map[ipld.PathSegment]ipld.Node) (ipld.Node, error) {
+	nb := n.Prototype().NewBuilder()
+	switch n.Kind() {
+	case ipld.Kind_Map:
+		ma, err := nb.BeginMap(n.Length())
+		if err != nil {
+			return nil, err
+		}
+		for itr := n.MapIterator(); !itr.Done(); {
+			k, v, err := itr.Next()
+			if err != nil {
+				return nil, err
+			}
+			ks, err := k.AsString()
+			if err != nil {
+				return nil, err
+			}
+			if err := ma.AssembleKey().AssignNode(k); err != nil {
+				return nil, err
+			}
+			if replacement, ok := newChildren[ipld.PathSegmentOfString(ks)]; ok {
+				v = replacement
+			}
+			if err := ma.AssembleValue().AssignNode(v); err != nil {
+				return nil, err
+			}
+		}
+		if err := ma.Finish(); err != nil {
+			return nil, err
+		}
+	case ipld.Kind_List:
+		la, err := nb.BeginList(n.Length())
+		if err != nil {
+			return nil, err
+		}
+		for itr := n.ListIterator(); !itr.Done(); {
+			idx, v, err := itr.Next()
+			if err != nil {
+				return nil, err
+			}
+			if replacement, ok := newChildren[ipld.PathSegmentOfInt(idx)]; ok {
+				v = replacement
+			}
+			if err := la.AssembleValue().AssignNode(v); err != nil {
+				return nil, err
+			}
+		}
+		if err := la.Finish(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("cannot rebuild node of kind %s with replaced children", n.Kind())
+	}
+	return nb.Build(), nil
+}
+
+// store encodes and writes n as a new block, using the link prototype of
+// prog.LastBlock.Link, and returns a Link-kind node wrapping the new link so the parent
+// keeps the same link-shaped structure the original DAG had. walk calls it only for a
+// node it has just loaded across a link. If prog carries no LastBlock link (nothing on
+// the way to n was loaded across a link, as for the root), there's nothing to store;
+// n stands in directly.
+func (tw *transformWalker) store(prog walker.Progress, n ipld.Node) (ipld.Node, error) {
+	if prog.LastBlock.Link == nil {
+		return n, nil
+	}
+	lp := prog.LastBlock.Link.Prototype()
+	lnk, err := tw.config.LinkSystem.Store(ipld.LinkContext{Ctx: tw.config.Ctx, LinkPath: prog.Path}, lp, n)
+	if err != nil {
+		return nil, fmt.Errorf("error storing rebuilt node at %q: %w", prog.Path, err)
+	}
+	tw.newLinks = append(tw.newLinks, lnk)
+	nb := basicnode.Prototype.Link.NewBuilder()
+	if err := nb.AssignLink(lnk); err != nil {
+		return nil, err
+	}
+	return nb.Build(), nil
+}
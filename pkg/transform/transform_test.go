@@ -0,0 +1,269 @@
+package transform_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-ipld-prime"
+	_ "github.com/ipld/go-ipld-prime/codec/dagcbor"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+	"github.com/ipld/go-ipld-prime/traversal/selector"
+	"github.com/ipld/go-ipld-prime/traversal/selector/builder"
+	"github.com/multiformats/go-multihash"
+
+	walker "github.com/ipld/go-walker/pkg"
+	"github.com/ipld/go-walker/pkg/transform"
+)
+
+var testPrefix = cid.Prefix{
+	Version:  1,
+	Codec:    cid.DagCBOR,
+	MhType:   multihash.SHA2_256,
+	MhLength: -1,
+}
+
+// newTestLinkSystem returns a LinkSystem backed by a plain in-memory map, which is all
+// Store/Load round-tripping in these tests needs.
+func newTestLinkSystem() ipld.LinkSystem {
+	blocks := make(map[string][]byte)
+	lsys := cidlink.DefaultLinkSystem()
+	lsys.StorageWriteOpener = func(lnkCtx ipld.LinkContext) (io.Writer, ipld.BlockWriteCommitter, error) {
+		var buf bytes.Buffer
+		return &buf, func(lnk ipld.Link) error {
+			blocks[lnk.(cidlink.Link).Cid.KeyString()] = buf.Bytes()
+			return nil
+		}, nil
+	}
+	lsys.StorageReadOpener = func(lnkCtx ipld.LinkContext, lnk ipld.Link) (io.Reader, error) {
+		data, ok := blocks[lnk.(cidlink.Link).Cid.KeyString()]
+		if !ok {
+			return nil, fmt.Errorf("no block for %q", lnk)
+		}
+		return bytes.NewReader(data), nil
+	}
+	return lsys
+}
+
+// storeMap builds a map node via build and stores it as a new block, returning the link.
+func storeMap(t *testing.T, lsys ipld.LinkSystem, build func(ipld.MapAssembler)) ipld.Link {
+	t.Helper()
+	nb := basicnode.Prototype.Map.NewBuilder()
+	ma, err := nb.BeginMap(-1)
+	if err != nil {
+		t.Fatalf("begin map: %s", err)
+	}
+	build(ma)
+	if err := ma.Finish(); err != nil {
+		t.Fatalf("finish map: %s", err)
+	}
+	lnk, err := lsys.Store(ipld.LinkContext{Ctx: context.Background()}, cidlink.LinkPrototype{Prefix: testPrefix}, nb.Build())
+	if err != nil {
+		t.Fatalf("store: %s", err)
+	}
+	return lnk
+}
+
+// allSelector matches and recurses into every node in the DAG.
+func allSelector(t *testing.T) selector.Selector {
+	t.Helper()
+	ssb := builder.NewSelectorSpecBuilder(basicnode.Prototype.Any)
+	sel, err := ssb.ExploreRecursive(selector.RecursionLimitDepth(16), ssb.ExploreUnion(
+		ssb.Matcher(),
+		ssb.ExploreAll(ssb.ExploreRecursiveEdge()),
+	)).Selector()
+	if err != nil {
+		t.Fatalf("building selector: %s", err)
+	}
+	return sel
+}
+
+func TestIdentityTransformLeavesNodeUnchanged(t *testing.T) {
+	lsys := newTestLinkSystem()
+	nb := basicnode.Prototype.Map.NewBuilder()
+	ma, err := nb.BeginMap(1)
+	if err != nil {
+		t.Fatalf("begin map: %s", err)
+	}
+	ma.AssembleKey().AssignString("greeting")
+	ma.AssembleValue().AssignString("hello")
+	if err := ma.Finish(); err != nil {
+		t.Fatalf("finish map: %s", err)
+	}
+	root := nb.Build()
+
+	newRoot, newLinks, err := transform.WalkTransforming(walker.Config{
+		Ctx:         context.Background(),
+		LinkSystem:  lsys,
+		Root:        root,
+		Selector:    allSelector(t),
+		TransformFn: func(walker.Progress, ipld.Node) (ipld.Node, error) { return nil, nil },
+	})
+	if err != nil {
+		t.Fatalf("WalkTransforming: %s", err)
+	}
+	if len(newLinks) != 0 {
+		t.Fatalf("expected no new links for an identity transform, got %d", len(newLinks))
+	}
+	v, err := newRoot.LookupByString("greeting")
+	if err != nil {
+		t.Fatalf("looking up greeting: %s", err)
+	}
+	s, _ := v.AsString()
+	if s != "hello" {
+		t.Fatalf("expected greeting to be unchanged, got %q", s)
+	}
+}
+
+func TestWalkTransformingRespectsNodeBudget(t *testing.T) {
+	lsys := newTestLinkSystem()
+	nb := basicnode.Prototype.Map.NewBuilder()
+	ma, err := nb.BeginMap(1)
+	if err != nil {
+		t.Fatalf("begin map: %s", err)
+	}
+	ma.AssembleKey().AssignString("greeting")
+	ma.AssembleValue().AssignString("hello")
+	if err := ma.Finish(); err != nil {
+		t.Fatalf("finish map: %s", err)
+	}
+	root := nb.Build()
+
+	_, _, err = transform.WalkTransforming(walker.Config{
+		Ctx:         context.Background(),
+		LinkSystem:  lsys,
+		Root:        root,
+		Selector:    allSelector(t),
+		Budget:      &walker.Budget{NodeBudget: 0, LinkBudget: 1},
+		TransformFn: func(walker.Progress, ipld.Node) (ipld.Node, error) { return nil, nil },
+	})
+	var budgetErr walker.ErrBudgetExceeded
+	if !errors.As(err, &budgetErr) {
+		t.Fatalf("expected ErrBudgetExceeded, got %v", err)
+	}
+	if budgetErr.BudgetKind != "node" {
+		t.Fatalf("expected node budget kind, got %q", budgetErr.BudgetKind)
+	}
+}
+
+func TestLeafReplacement(t *testing.T) {
+	lsys := newTestLinkSystem()
+	nb := basicnode.Prototype.Map.NewBuilder()
+	ma, err := nb.BeginMap(1)
+	if err != nil {
+		t.Fatalf("begin map: %s", err)
+	}
+	ma.AssembleKey().AssignString("greeting")
+	ma.AssembleValue().AssignString("hello")
+	if err := ma.Finish(); err != nil {
+		t.Fatalf("finish map: %s", err)
+	}
+	root := nb.Build()
+
+	newRoot, newLinks, err := transform.WalkTransforming(walker.Config{
+		Ctx:        context.Background(),
+		LinkSystem: lsys,
+		Root:       root,
+		Selector:   allSelector(t),
+		TransformFn: func(prog walker.Progress, n ipld.Node) (ipld.Node, error) {
+			if n.Kind() != ipld.Kind_String {
+				return nil, nil
+			}
+			return basicnode.NewString("goodbye"), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("WalkTransforming: %s", err)
+	}
+	if len(newLinks) != 0 {
+		t.Fatalf("expected no new links: leaf replacement never crosses a link boundary, got %d", len(newLinks))
+	}
+	v, err := newRoot.LookupByString("greeting")
+	if err != nil {
+		t.Fatalf("looking up greeting: %s", err)
+	}
+	s, _ := v.AsString()
+	if s != "goodbye" {
+		t.Fatalf("expected greeting to be replaced, got %q", s)
+	}
+}
+
+func TestMidTreeLinkRewrite(t *testing.T) {
+	lsys := newTestLinkSystem()
+
+	childLnk := storeMap(t, lsys, func(ma ipld.MapAssembler) {
+		ma.AssembleKey().AssignString("leaf")
+		ma.AssembleValue().AssignString("old")
+	})
+
+	nb := basicnode.Prototype.Map.NewBuilder()
+	ma, err := nb.BeginMap(1)
+	if err != nil {
+		t.Fatalf("begin map: %s", err)
+	}
+	ma.AssembleKey().AssignString("child")
+	if err := ma.AssembleValue().AssignLink(childLnk); err != nil {
+		t.Fatalf("assigning link: %s", err)
+	}
+	if err := ma.Finish(); err != nil {
+		t.Fatalf("finish map: %s", err)
+	}
+	root := nb.Build()
+
+	newRoot, newLinks, err := transform.WalkTransforming(walker.Config{
+		Ctx:                            context.Background(),
+		LinkSystem:                     lsys,
+		LinkTargetNodePrototypeChooser: func(ipld.Link, ipld.LinkContext) (ipld.NodePrototype, error) { return basicnode.Prototype.Any, nil },
+		Root:                           root,
+		Selector:                       allSelector(t),
+		TransformFn: func(prog walker.Progress, n ipld.Node) (ipld.Node, error) {
+			if n.Kind() != ipld.Kind_String {
+				return nil, nil
+			}
+			s, _ := n.AsString()
+			if s != "old" {
+				return nil, nil
+			}
+			return basicnode.NewString("new"), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("WalkTransforming: %s", err)
+	}
+	if len(newLinks) != 1 {
+		t.Fatalf("expected exactly one rewritten block, got %d", len(newLinks))
+	}
+	if newLinks[0].String() == childLnk.String() {
+		t.Fatalf("expected a new link distinct from the original child link")
+	}
+
+	childField, err := newRoot.LookupByString("child")
+	if err != nil {
+		t.Fatalf("looking up child: %s", err)
+	}
+	newChildLnk, err := childField.AsLink()
+	if err != nil {
+		t.Fatalf("child is no longer a link: %s", err)
+	}
+	if newChildLnk.String() != newLinks[0].String() {
+		t.Fatalf("expected root's child link to be updated to the new block")
+	}
+
+	newChild, err := lsys.Load(ipld.LinkContext{Ctx: context.Background()}, newChildLnk, basicnode.Prototype.Any)
+	if err != nil {
+		t.Fatalf("loading rewritten child: %s", err)
+	}
+	leaf, err := newChild.LookupByString("leaf")
+	if err != nil {
+		t.Fatalf("looking up leaf: %s", err)
+	}
+	leafStr, _ := leaf.AsString()
+	if leafStr != "new" {
+		t.Fatalf("expected leaf to be rewritten, got %q", leafStr)
+	}
+}
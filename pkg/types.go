@@ -23,6 +23,45 @@ type Config struct {
 	Root                           ipld.Node
 	Selector                       selector.Selector
 	Visitor                        AdvVisitFn
+	Budget                         *Budget                    // optional; if set, bounds how many nodes may be visited and links loaded before the traversal aborts with ErrBudgetExceeded.
+	ResumeState                    *TraversalResumerPathState // optional; if set, every link load is recorded into it, and (together with ResumeAt) it can be used to fast-forward a later walk.
+	ResumeAt                       ipld.Path                  // optional; if non-zero, the walk fast-forwards to this path (using ResumeState, which must also be set) before engaging normal selector exploration.
+	TransformFn                    TransformFn                // used by pkg/transform's rewriting walker; ignored by read-only walkers.
+	Preloader                      PreloaderFn                // optional; called with each batch of child links discovered by queue.QueuedNode.Children, before they are dequeued and resolved.
+}
+
+// PreloadLink describes one child link discovered during traversal that is about to
+// enter the queue, for the benefit of Config.Preloader.
+type PreloadLink struct {
+	Path ipld.Path
+	Link ipld.Link
+}
+
+// PreloaderFn is called synchronously from queue.QueuedNode.Children with each batch of
+// child links about to enter the queue. It's purely advisory: the walker still loads
+// each link through LinkSystem as usual once it dequeues and resolves that child.
+type PreloaderFn func(ctx context.Context, links []PreloadLink)
+
+// Budget constrains how much work a traversal is allowed to do before it aborts.
+//
+// A Budget is carried in Progress rather than shared globally: at each exploration
+// point for a map or list, the remaining budget is cloned and handed to every child,
+// mirroring the way Progress.Path is extended per child. This means the budget is
+// really scoped per depth-first descent -- it bounds how deep and how link-heavy any
+// single branch may get, rather than acting as one traversal-wide counter.
+type Budget struct {
+	NodeBudget int64 // decremented once per node visited; see queue.QueuedNode.Visit.
+	LinkBudget int64 // decremented once per link loaded; see queue.QueuedNode.Resolve.
+}
+
+// Clone returns a copy of the budget, or nil if b is nil. Callers use this to hand an
+// independent budget to each child at an exploration point.
+func (b *Budget) Clone() *Budget {
+	if b == nil {
+		return nil
+	}
+	c := *b
+	return &c
 }
 
 type Progress struct {
@@ -31,6 +70,7 @@ type Progress struct {
 		Path ipld.Path
 		Link ipld.Link
 	}
+	Budget *Budget // Budget, if the traversal was configured with one; cloned into each child at exploration points, see Budget.Clone.
 }
 
 // AdvVisitFn is like VisitFn, but for use with AdvTraversal: it gets additional arguments describing *why* this node is visited.